@@ -0,0 +1,177 @@
+package keys
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/nacl/secretbox"
+
+	"github.com/binance-chain/go-sdk/common"
+	"github.com/binance-chain/go-sdk/common/crypto"
+	"github.com/binance-chain/go-sdk/common/crypto/secp256k1"
+	"github.com/binance-chain/go-sdk/types"
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+// Block types and headers for the ASCII-armored format, mirroring the Cosmos
+// SDK's mintkey package so backups can move between this SDK and cosmos-sdk-
+// based tooling.
+const (
+	blockTypePrivKey = "TENDERMINT PRIVATE KEY"
+	blockTypePubKey  = "TENDERMINT PUBLIC KEY"
+
+	armorHeaderKDF  = "kdf"
+	armorHeaderSalt = "salt"
+	armorKDFBcrypt  = "bcrypt"
+
+	// bcryptSecurityParameter is the bcrypt cost used to stretch the passphrase.
+	bcryptSecurityParameter = 12
+)
+
+var secretboxNonce [24]byte
+
+// ExportAsArmor returns a PGP-style ASCII-armored, passphrase-encrypted backup of
+// the private key: a single string that pastes cleanly into chats or emails,
+// distinct from the JSON keystore produced by ExportAsKeyStore.
+func (m *keyManager) ExportAsArmor(passphrase string) (string, error) {
+	return encryptArmorPrivKey(m.privKey, passphrase)
+}
+
+// ExportAsPubKeyArmor returns an unencrypted, ASCII-armored export of the public
+// key, for handing to an offline signer or a watch-only NewOfflineKeyManager.
+func (m *keyManager) ExportAsPubKeyArmor() (string, error) {
+	return armorPubKey(m.privKey.PubKey()), nil
+}
+
+// NewArmoredKeyManager recovers a key manager from a backup produced by
+// ExportAsArmor.
+func NewArmoredKeyManager(armor, passphrase string) (KeyManager, error) {
+	k := keyManager{}
+	err := k.recoveryFromArmor(armor, passphrase)
+	return &k, err
+}
+
+func (m *keyManager) recoveryFromArmor(armor, passphrase string) error {
+	privKey, err := unarmorDecryptPrivKey(armor, passphrase)
+	if err != nil {
+		return err
+	}
+	secpPrivKey, ok := privKey.(secp256k1.PrivKeySecp256k1)
+	if !ok {
+		return fmt.Errorf(" Only PrivKeySecp256k1 key is supported ")
+	}
+	m.privKey = secpPrivKey
+	m.addr = types.AccAddress(secpPrivKey.PubKey().Address())
+	return nil
+}
+
+func encryptArmorPrivKey(privKey crypto.PrivKey, passphrase string) (string, error) {
+	saltBytes, encBytes, err := encryptPrivKey(privKey, passphrase)
+	if err != nil {
+		return "", err
+	}
+	headers := map[string]string{
+		armorHeaderKDF:  armorKDFBcrypt,
+		armorHeaderSalt: fmt.Sprintf("%X", saltBytes),
+	}
+	return encodeArmor(blockTypePrivKey, headers, encBytes), nil
+}
+
+func encryptPrivKey(privKey crypto.PrivKey, passphrase string) (saltBytes, encBytes []byte, err error) {
+	saltBytes, err = common.GenerateRandomBytes(16)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := bcryptKey(saltBytes, passphrase)
+	if err != nil {
+		return nil, nil, err
+	}
+	privKeyBytes := tx.Cdc.MustMarshalBinaryBare(privKey)
+	return saltBytes, secretbox.Seal(nil, privKeyBytes, &secretboxNonce, key), nil
+}
+
+func unarmorDecryptPrivKey(armorStr, passphrase string) (crypto.PrivKey, error) {
+	blockType, headers, encBytes, err := decodeArmor(armorStr)
+	if err != nil {
+		return nil, err
+	}
+	if blockType != blockTypePrivKey {
+		return nil, fmt.Errorf("unrecognized armor type %q, expected %q", blockType, blockTypePrivKey)
+	}
+	if headers[armorHeaderKDF] != armorKDFBcrypt {
+		return nil, fmt.Errorf("unrecognized KDF type %q", headers[armorHeaderKDF])
+	}
+	saltBytes, err := hex.DecodeString(headers[armorHeaderSalt])
+	if err != nil {
+		return nil, fmt.Errorf("error decoding salt: %s", err.Error())
+	}
+	key, err := bcryptKey(saltBytes, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	privKeyBytes, ok := secretbox.Open(nil, encBytes, &secretboxNonce, key)
+	if !ok {
+		return nil, fmt.Errorf("failed to decrypt private key: wrong passphrase")
+	}
+	var privKey crypto.PrivKey
+	if err := tx.Cdc.UnmarshalBinaryBare(privKeyBytes, &privKey); err != nil {
+		return nil, err
+	}
+	return privKey, nil
+}
+
+// bcryptKey stretches salt+passphrase through bcrypt, then sha256-hashes the
+// result down to the 32 bytes secretbox needs as a key. bcrypt.GenerateFromPassword
+// has no separate salt argument, so the salt is prepended to the password being
+// hashed instead. Using a fresh random salt per export means the all-zero
+// secretbox nonce never gets reused under the same key.
+func bcryptKey(saltBytes []byte, passphrase string) (*[32]byte, error) {
+	hashed, err := bcrypt.GenerateFromPassword(append(saltBytes, []byte(passphrase)...), bcryptSecurityParameter)
+	if err != nil {
+		return nil, fmt.Errorf("error generating bcrypt key from passphrase: %s", err.Error())
+	}
+	key := sha256.Sum256(hashed)
+	return &key, nil
+}
+
+func armorPubKey(pubKey crypto.PubKey) string {
+	bz := tx.Cdc.MustMarshalBinaryBare(pubKey)
+	return encodeArmor(blockTypePubKey, nil, bz)
+}
+
+// UnarmorPubKey recovers a public key previously exported with
+// ExportAsPubKeyArmor.
+func UnarmorPubKey(armorStr string) (crypto.PubKey, error) {
+	blockType, _, bz, err := decodeArmor(armorStr)
+	if err != nil {
+		return nil, err
+	}
+	if blockType != blockTypePubKey {
+		return nil, fmt.Errorf("unrecognized armor type %q, expected %q", blockType, blockTypePubKey)
+	}
+	var pubKey crypto.PubKey
+	if err := tx.Cdc.UnmarshalBinaryBare(bz, &pubKey); err != nil {
+		return nil, err
+	}
+	return pubKey, nil
+}
+
+func encodeArmor(blockType string, headers map[string]string, data []byte) string {
+	block := &pem.Block{
+		Type:    blockType,
+		Headers: headers,
+		Bytes:   data,
+	}
+	return string(pem.EncodeToMemory(block))
+}
+
+func decodeArmor(armorStr string) (blockType string, headers map[string]string, data []byte, err error) {
+	block, _ := pem.Decode([]byte(armorStr))
+	if block == nil {
+		return "", nil, nil, fmt.Errorf("couldn't decode armored block")
+	}
+	return block.Type, block.Headers, block.Bytes, nil
+}
@@ -0,0 +1,59 @@
+package keys
+
+import "testing"
+
+func TestExportAsArmorRoundTrip(t *testing.T) {
+	km, err := NewKeyManager()
+	if err != nil {
+		t.Fatalf("NewKeyManager returned error: %s", err)
+	}
+
+	armor, err := km.ExportAsArmor("passphrase")
+	if err != nil {
+		t.Fatalf("ExportAsArmor returned error: %s", err)
+	}
+
+	recovered, err := NewArmoredKeyManager(armor, "passphrase")
+	if err != nil {
+		t.Fatalf("NewArmoredKeyManager returned error: %s", err)
+	}
+	if recovered.GetAddr().String() != km.GetAddr().String() {
+		t.Fatalf("recovered key manager has a different address: %s != %s", recovered.GetAddr(), km.GetAddr())
+	}
+}
+
+func TestExportAsArmorWrongPassphrase(t *testing.T) {
+	km, err := NewKeyManager()
+	if err != nil {
+		t.Fatalf("NewKeyManager returned error: %s", err)
+	}
+
+	armor, err := km.ExportAsArmor("passphrase")
+	if err != nil {
+		t.Fatalf("ExportAsArmor returned error: %s", err)
+	}
+
+	if _, err := NewArmoredKeyManager(armor, "wrong-passphrase"); err == nil {
+		t.Fatalf("expected NewArmoredKeyManager to fail with the wrong passphrase")
+	}
+}
+
+func TestExportAsPubKeyArmorRoundTrip(t *testing.T) {
+	km, err := NewKeyManager()
+	if err != nil {
+		t.Fatalf("NewKeyManager returned error: %s", err)
+	}
+
+	armor, err := km.ExportAsPubKeyArmor()
+	if err != nil {
+		t.Fatalf("ExportAsPubKeyArmor returned error: %s", err)
+	}
+
+	pub, err := UnarmorPubKey(armor)
+	if err != nil {
+		t.Fatalf("UnarmorPubKey returned error: %s", err)
+	}
+	if pub.Address().String() != km.GetPrivKey().PubKey().Address().String() {
+		t.Fatalf("UnarmorPubKey returned a different pubkey than the original key manager")
+	}
+}
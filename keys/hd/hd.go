@@ -0,0 +1,138 @@
+// Package hd implements parsing and formatting of BIP44 hierarchical
+// deterministic derivation paths ("purpose'/coinType'/account'/change/addressIndex"),
+// used to derive more than one key from a single mnemonic.
+package hd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// purposeFundraiser is the BIP44 purpose used by the original Cosmos fundraiser path.
+	purposeFundraiser uint32 = 44
+	// coinTypeFundraiser is Binance Chain's registered SLIP-44 coin type.
+	coinTypeFundraiser uint32 = 714
+
+	hardenedOffset uint32 = 1 << 31
+)
+
+// BIP44Params represents a BIP44 derivation path: m/purpose'/coinType'/account'/change/addressIndex.
+// Purpose, coinType and account are always hardened; change and addressIndex are not.
+type BIP44Params struct {
+	Purpose      uint32 `json:"purpose"`
+	CoinType     uint32 `json:"coinType"`
+	Account      uint32 `json:"account"`
+	Change       bool   `json:"change"`
+	AddressIndex uint32 `json:"addressIndex"`
+}
+
+// NewParams builds a BIP44Params from its components.
+func NewParams(purpose, coinType, account uint32, change bool, addressIndex uint32) BIP44Params {
+	return BIP44Params{
+		Purpose:      purpose,
+		CoinType:     coinType,
+		Account:      account,
+		Change:       change,
+		AddressIndex: addressIndex,
+	}
+}
+
+// NewFundraiserParams returns the BIP44 path for the given account/addressIndex
+// pair under Binance Chain's fundraiser purpose/coinType (44'/714').
+func NewFundraiserParams(account, addressIndex uint32) BIP44Params {
+	return NewParams(purposeFundraiser, coinTypeFundraiser, account, false, addressIndex)
+}
+
+// NewParamsFromPath parses a derivation path such as "44'/714'/0'/0/0" into a
+// BIP44Params. Both "'" and "h"/"H" are accepted as the hardened marker.
+func NewParamsFromPath(path string) (BIP44Params, error) {
+	parts := strings.Split(path, "/")
+	if len(parts) != 5 {
+		return BIP44Params{}, fmt.Errorf("invalid BIP44 path %q: expected 5 elements, got %d", path, len(parts))
+	}
+
+	purpose, hardened, err := parseElement(parts[0])
+	if err != nil {
+		return BIP44Params{}, err
+	}
+	if !hardened {
+		return BIP44Params{}, fmt.Errorf("invalid BIP44 path %q: purpose must be hardened", path)
+	}
+
+	coinType, hardened, err := parseElement(parts[1])
+	if err != nil {
+		return BIP44Params{}, err
+	}
+	if !hardened {
+		return BIP44Params{}, fmt.Errorf("invalid BIP44 path %q: coinType must be hardened", path)
+	}
+
+	account, hardened, err := parseElement(parts[2])
+	if err != nil {
+		return BIP44Params{}, err
+	}
+	if !hardened {
+		return BIP44Params{}, fmt.Errorf("invalid BIP44 path %q: account must be hardened", path)
+	}
+
+	changeNum, hardened, err := parseElement(parts[3])
+	if err != nil {
+		return BIP44Params{}, err
+	}
+	if hardened {
+		return BIP44Params{}, fmt.Errorf("invalid BIP44 path %q: change must not be hardened", path)
+	}
+	if changeNum > 1 {
+		return BIP44Params{}, fmt.Errorf("invalid BIP44 path %q: change must be 0 or 1", path)
+	}
+
+	addressIndex, hardened, err := parseElement(parts[4])
+	if err != nil {
+		return BIP44Params{}, err
+	}
+	if hardened {
+		return BIP44Params{}, fmt.Errorf("invalid BIP44 path %q: addressIndex must not be hardened", path)
+	}
+
+	return NewParams(purpose, coinType, account, changeNum == 1, addressIndex), nil
+}
+
+func parseElement(s string) (value uint32, hardened bool, err error) {
+	if strings.HasSuffix(s, "'") || strings.HasSuffix(s, "h") || strings.HasSuffix(s, "H") {
+		hardened = true
+		s = s[:len(s)-1]
+	}
+	n, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid BIP44 path element %q: %s", s, err.Error())
+	}
+	return uint32(n), hardened, nil
+}
+
+// String formats the path back into "purpose'/coinType'/account'/change/addressIndex".
+func (p BIP44Params) String() string {
+	change := "0"
+	if p.Change {
+		change = "1"
+	}
+	return fmt.Sprintf("%d'/%d'/%d'/%s/%d", p.Purpose, p.CoinType, p.Account, change, p.AddressIndex)
+}
+
+// DerivationPath returns the path as the raw child indexes a BIP32 derivation
+// walks through, with the hardened offset already applied to purpose, coinType
+// and account.
+func (p BIP44Params) DerivationPath() []uint32 {
+	change := uint32(0)
+	if p.Change {
+		change = 1
+	}
+	return []uint32{
+		p.Purpose + hardenedOffset,
+		p.CoinType + hardenedOffset,
+		p.Account + hardenedOffset,
+		change,
+		p.AddressIndex,
+	}
+}
@@ -0,0 +1,73 @@
+package hd
+
+import "testing"
+
+func TestNewParamsFromPath(t *testing.T) {
+	cases := []struct {
+		path     string
+		expected BIP44Params
+	}{
+		{"44'/714'/0'/0/0", NewFundraiserParams(0, 0)},
+		{"44'/714'/0'/0/1", NewFundraiserParams(0, 1)},
+		{"44'/714'/1'/0/0", NewFundraiserParams(1, 0)},
+		{"44'/714'/0'/1/0", NewParams(44, 714, 0, true, 0)},
+		{"44h/60h/0h/0/0", NewParams(44, 60, 0, false, 0)},
+	}
+	for _, c := range cases {
+		got, err := NewParamsFromPath(c.path)
+		if err != nil {
+			t.Fatalf("NewParamsFromPath(%q) returned error: %s", c.path, err)
+		}
+		if got != c.expected {
+			t.Fatalf("NewParamsFromPath(%q) = %+v, want %+v", c.path, got, c.expected)
+		}
+	}
+}
+
+func TestNewParamsFromPathInvalid(t *testing.T) {
+	cases := []string{
+		"",
+		"44'/714'/0'/0",
+		"44/714'/0'/0/0",
+		"44'/714'/0'/1'/0",
+		"44'/714'/0'/2/0",
+		"abc'/714'/0'/0/0",
+	}
+	for _, path := range cases {
+		if _, err := NewParamsFromPath(path); err == nil {
+			t.Fatalf("NewParamsFromPath(%q) expected an error, got none", path)
+		}
+	}
+}
+
+func TestStringRoundTrip(t *testing.T) {
+	params := []BIP44Params{
+		NewFundraiserParams(0, 0),
+		NewFundraiserParams(0, 1),
+		NewFundraiserParams(2, 5),
+		NewParams(44, 714, 0, true, 3),
+	}
+	for _, p := range params {
+		roundTripped, err := NewParamsFromPath(p.String())
+		if err != nil {
+			t.Fatalf("NewParamsFromPath(%q) returned error: %s", p.String(), err)
+		}
+		if roundTripped != p {
+			t.Fatalf("round trip of %+v produced %+v", p, roundTripped)
+		}
+	}
+}
+
+func TestDerivationPath(t *testing.T) {
+	p := NewFundraiserParams(0, 0)
+	got := p.DerivationPath()
+	want := []uint32{44 + hardenedOffset, 714 + hardenedOffset, 0 + hardenedOffset, 0, 0}
+	if len(got) != len(want) {
+		t.Fatalf("DerivationPath() length = %d, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("DerivationPath()[%d] = %d, want %d", i, got[i], want[i])
+		}
+	}
+}
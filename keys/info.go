@@ -0,0 +1,159 @@
+package keys
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/binance-chain/go-sdk/common/crypto"
+	"github.com/binance-chain/go-sdk/common/crypto/ledger"
+	"github.com/binance-chain/go-sdk/types"
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+// KeyType reflects a public key's type.
+type KeyType uint
+
+const (
+	// TypeLocal is a private key backed by local, encrypted storage.
+	TypeLocal KeyType = iota
+	// TypeLedger is a key whose private material never leaves a connected Ledger device.
+	TypeLedger
+	// TypeOffline is a watch-only key: only the public key is known, signing happens elsewhere.
+	TypeOffline
+)
+
+var keyTypeNames = map[KeyType]string{
+	TypeLocal:   "local",
+	TypeLedger:  "ledger",
+	TypeOffline: "offline",
+}
+
+func (kt KeyType) String() string {
+	if name, ok := keyTypeNames[kt]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// Info is the public record a Keybase stores and returns for every named key.
+// Concrete implementations never expose decrypted private key material directly;
+// that is only produced on demand by Keybase.Sign.
+type Info interface {
+	// GetName returns the name under which this key is stored.
+	GetName() string
+	// GetType returns whether the key is local, ledger-backed or offline/watch-only.
+	GetType() KeyType
+	// GetPubKey returns the public key.
+	GetPubKey() crypto.PubKey
+	// GetAddress returns the address derived from the public key.
+	GetAddress() types.AccAddress
+}
+
+// encodePubKey amino-marshals pub through tx.Cdc, the same codec used elsewhere
+// in this package for crypto.PubKey/PrivKey, and hex-encodes the result so it
+// round-trips through plain JSON. encoding/json alone cannot decode into a
+// crypto.PubKey interface field: it has no registry to pick a concrete type.
+func encodePubKey(pub crypto.PubKey) string {
+	return hex.EncodeToString(tx.Cdc.MustMarshalBinaryBare(pub))
+}
+
+func decodePubKey(s string) (crypto.PubKey, error) {
+	bz, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding pub key: %s", err.Error())
+	}
+	var pub crypto.PubKey
+	if err := tx.Cdc.UnmarshalBinaryBare(bz, &pub); err != nil {
+		return nil, err
+	}
+	return pub, nil
+}
+
+// localInfo is the Info for a key whose private key is stored locally, encrypted at rest.
+type localInfo struct {
+	Name             string `json:"name"`
+	PubKeyArmor      string `json:"pub_key"`
+	EncryptedPrivKey string `json:"encrypted_priv_key"`
+
+	pubKey crypto.PubKey
+}
+
+func newLocalInfo(name string, pub crypto.PubKey, encryptedPrivKey string) Info {
+	return &localInfo{Name: name, PubKeyArmor: encodePubKey(pub), EncryptedPrivKey: encryptedPrivKey, pubKey: pub}
+}
+
+func (i *localInfo) hydrate() error {
+	pub, err := decodePubKey(i.PubKeyArmor)
+	if err != nil {
+		return err
+	}
+	i.pubKey = pub
+	return nil
+}
+
+func (i localInfo) GetName() string             { return i.Name }
+func (i localInfo) GetType() KeyType             { return TypeLocal }
+func (i localInfo) GetPubKey() crypto.PubKey     { return i.pubKey }
+func (i localInfo) GetAddress() types.AccAddress { return types.AccAddress(i.pubKey.Address()) }
+
+// ledgerInfo is the Info for a key that lives on a Ledger hardware device.
+type ledgerInfo struct {
+	Name        string                `json:"name"`
+	PubKeyArmor string                `json:"pub_key"`
+	Path        ledger.DerivationPath `json:"path"`
+
+	pubKey crypto.PubKey
+}
+
+func newLedgerInfo(name string, pub crypto.PubKey, path ledger.DerivationPath) Info {
+	return &ledgerInfo{Name: name, PubKeyArmor: encodePubKey(pub), Path: path, pubKey: pub}
+}
+
+func (i *ledgerInfo) hydrate() error {
+	pub, err := decodePubKey(i.PubKeyArmor)
+	if err != nil {
+		return err
+	}
+	i.pubKey = pub
+	return nil
+}
+
+func (i ledgerInfo) GetName() string             { return i.Name }
+func (i ledgerInfo) GetType() KeyType             { return TypeLedger }
+func (i ledgerInfo) GetPubKey() crypto.PubKey     { return i.pubKey }
+func (i ledgerInfo) GetAddress() types.AccAddress { return types.AccAddress(i.pubKey.Address()) }
+
+// offlineInfo is the Info for a watch-only key: the public key is known but signing
+// must happen with an external, out-of-process signer.
+type offlineInfo struct {
+	Name        string `json:"name"`
+	PubKeyArmor string `json:"pub_key"`
+
+	pubKey crypto.PubKey
+}
+
+func newOfflineInfo(name string, pub crypto.PubKey) Info {
+	return &offlineInfo{Name: name, PubKeyArmor: encodePubKey(pub), pubKey: pub}
+}
+
+func (i *offlineInfo) hydrate() error {
+	pub, err := decodePubKey(i.PubKeyArmor)
+	if err != nil {
+		return err
+	}
+	i.pubKey = pub
+	return nil
+}
+
+func (i offlineInfo) GetName() string             { return i.Name }
+func (i offlineInfo) GetType() KeyType             { return TypeOffline }
+func (i offlineInfo) GetPubKey() crypto.PubKey     { return i.pubKey }
+func (i offlineInfo) GetAddress() types.AccAddress { return types.AccAddress(i.pubKey.Address()) }
+
+// infoWrapper is the on-disk envelope used to persist an Info along with enough of a
+// type tag to know which concrete struct to unmarshal into.
+type infoWrapper struct {
+	Type KeyType         `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
@@ -0,0 +1,377 @@
+package keys
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/go-bip39"
+
+	"github.com/binance-chain/go-sdk/common/crypto"
+	"github.com/binance-chain/go-sdk/common/crypto/ledger"
+	"github.com/binance-chain/go-sdk/common/crypto/secp256k1"
+	"github.com/binance-chain/go-sdk/keys/hd"
+)
+
+// SigningAlgo is the digital signature algorithm backing a key. Only secp256k1 is
+// supported today, but the type gives downstream storage formats and callers a
+// stable place to plug in others later.
+type SigningAlgo string
+
+const (
+	SigningAlgoSecp256k1 SigningAlgo = "secp256k1"
+)
+
+// Keybase manages a persistent, named collection of keys. Unlike KeyManager, which
+// wraps a single in-memory key, a Keybase is the long-lived store a CLI or wallet
+// application keeps on disk (or wherever KeybaseStorage points it at) across runs.
+type Keybase interface {
+	// CreateMnemonic generates a new mnemonic, derives a key from it along the
+	// default fundraiser path and stores it under name, encrypted with passphrase.
+	// It returns the created Info and the generated mnemonic.
+	CreateMnemonic(name, passphrase string, algo SigningAlgo) (info Info, mnemonic string, err error)
+	// CreateLedger stores a reference to a key held on a connected Ledger device;
+	// no private material is ever written to the Keybase.
+	CreateLedger(name string, algo SigningAlgo, hdPath ledger.DerivationPath) (Info, error)
+	// CreateOffline stores a watch-only key: signing must happen out of process.
+	CreateOffline(name string, pubkey crypto.PubKey) (Info, error)
+	// Derive recovers a key from an existing mnemonic along path and stores it
+	// under name, encrypted with encryptPassphrase.
+	Derive(name, mnemonic, bip39Passphrase string, path hd.BIP44Params, encryptPassphrase string) (Info, error)
+	// List returns every key stored in the Keybase.
+	List() ([]Info, error)
+	// Get returns the Info for name.
+	Get(name string) (Info, error)
+	// Delete removes name from the Keybase. passphrase is required for local keys.
+	Delete(name, passphrase string) error
+	// Update re-encrypts the local key stored under name with newpass.
+	Update(name, oldpass, newpass string) error
+	// Sign produces a signature over msg using the key stored under name,
+	// transparently dispatching to a Ledger device or local decryption depending
+	// on the key's type.
+	Sign(name, passphrase string, msg []byte) (sig []byte, pub crypto.PubKey, err error)
+	// Export returns an ASCII-armored, passphrase-encrypted backup of the named key.
+	Export(name, passphrase string) (armor string, err error)
+	// Import restores a key previously produced by Export, storing it under name.
+	// passphrase must match the one the export was encrypted with.
+	Import(name, armor, passphrase string) error
+}
+
+type keybase struct {
+	storage KeybaseStorage
+}
+
+// NewKeybase creates a Keybase backed by the default filesystem storage,
+// ~/.bnbcli/keys.
+func NewKeybase() (Keybase, error) {
+	dir, err := defaultKeyDBDir()
+	if err != nil {
+		return nil, err
+	}
+	storage, err := NewFileStorage(dir)
+	if err != nil {
+		return nil, err
+	}
+	return NewKeybaseWithStorage(storage), nil
+}
+
+// NewKeybaseWithStorage creates a Keybase backed by a caller-supplied
+// KeybaseStorage, letting downstream apps plug in their own database.
+func NewKeybaseWithStorage(storage KeybaseStorage) Keybase {
+	return &keybase{storage: storage}
+}
+
+func checkAlgo(algo SigningAlgo) error {
+	if algo != SigningAlgoSecp256k1 {
+		return fmt.Errorf("unsupported signing algo %q: only secp256k1 is supported", algo)
+	}
+	return nil
+}
+
+// checkKeyName rejects names that could escape a file-backed KeybaseStorage's
+// base directory (e.g. "../../etc/passwd") once joined into a path, since every
+// Keybase method below forwards its caller-supplied name straight through to
+// KeybaseStorage without any filesystem-safety checks of its own.
+func checkKeyName(name string) error {
+	if name == "" {
+		return fmt.Errorf("key name cannot be empty")
+	}
+	if strings.ContainsAny(name, `/\`) || strings.Contains(name, "..") {
+		return fmt.Errorf("invalid key name %q: must not contain path separators or \"..\"", name)
+	}
+	return nil
+}
+
+func (kb *keybase) writeInfo(info Info) error {
+	data, err := marshalInfo(info)
+	if err != nil {
+		return err
+	}
+	return kb.storage.Put(info.GetName(), data)
+}
+
+func (kb *keybase) CreateMnemonic(name, passphrase string, algo SigningAlgo) (Info, string, error) {
+	if err := checkAlgo(algo); err != nil {
+		return nil, "", err
+	}
+	entropy, err := bip39.NewEntropy(256)
+	if err != nil {
+		return nil, "", err
+	}
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	if err != nil {
+		return nil, "", err
+	}
+	info, err := kb.Derive(name, mnemonic, defaultBIP39Passphrase, hd.NewFundraiserParams(0, 0), passphrase)
+	if err != nil {
+		return nil, "", err
+	}
+	return info, mnemonic, nil
+}
+
+func (kb *keybase) Derive(name, mnemonic, bip39Passphrase string, path hd.BIP44Params, encryptPassphrase string) (Info, error) {
+	if err := checkKeyName(name); err != nil {
+		return nil, err
+	}
+	if err := checkMnemonic(mnemonic); err != nil {
+		return nil, err
+	}
+	if _, err := kb.storage.Get(name); err == nil {
+		return nil, fmt.Errorf("key %q already exists", name)
+	}
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, bip39Passphrase)
+	if err != nil {
+		return nil, err
+	}
+	masterPriv, ch := ComputeMastersFromSeed(seed)
+	derivedPriv, err := DerivePrivateKeyForPath(masterPriv, ch, path.String())
+	if err != nil {
+		return nil, err
+	}
+	privKey := secp256k1.PrivKeySecp256k1(derivedPriv)
+	encryptedKey, err := generateKeyStore(privKey, encryptPassphrase, nil)
+	if err != nil {
+		return nil, err
+	}
+	encryptedJSON, err := json.Marshal(encryptedKey)
+	if err != nil {
+		return nil, err
+	}
+	info := newLocalInfo(name, privKey.PubKey(), string(encryptedJSON))
+	if err := kb.writeInfo(info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+func (kb *keybase) CreateLedger(name string, algo SigningAlgo, hdPath ledger.DerivationPath) (Info, error) {
+	if err := checkKeyName(name); err != nil {
+		return nil, err
+	}
+	if err := checkAlgo(algo); err != nil {
+		return nil, err
+	}
+	if _, err := kb.storage.Get(name); err == nil {
+		return nil, fmt.Errorf("key %q already exists", name)
+	}
+	if ledger.DiscoverLedger == nil {
+		return nil, fmt.Errorf("no Ledger discovery function defined")
+	}
+	device, err := ledger.DiscoverLedger()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find ledger device: %s", err.Error())
+	}
+	pkl, err := ledger.GenLedgerSecp256k1Key(hdPath, device)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create PrivKeyLedgerSecp256k1: %s", err.Error())
+	}
+	info := newLedgerInfo(name, pkl.PubKey(), hdPath)
+	if err := kb.writeInfo(info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+func (kb *keybase) CreateOffline(name string, pubkey crypto.PubKey) (Info, error) {
+	if err := checkKeyName(name); err != nil {
+		return nil, err
+	}
+	if _, err := kb.storage.Get(name); err == nil {
+		return nil, fmt.Errorf("key %q already exists", name)
+	}
+	info := newOfflineInfo(name, pubkey)
+	if err := kb.writeInfo(info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+func (kb *keybase) List() ([]Info, error) {
+	names, err := kb.storage.List()
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]Info, 0, len(names))
+	for _, name := range names {
+		info, err := kb.Get(name)
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+func (kb *keybase) Get(name string) (Info, error) {
+	if err := checkKeyName(name); err != nil {
+		return nil, err
+	}
+	data, err := kb.storage.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalInfo(data)
+}
+
+func (kb *keybase) Delete(name, passphrase string) error {
+	info, err := kb.Get(name)
+	if err != nil {
+		return err
+	}
+	if local, ok := info.(*localInfo); ok {
+		if _, err := decryptLocalInfo(local, passphrase); err != nil {
+			return err
+		}
+	}
+	return kb.storage.Delete(name)
+}
+
+func (kb *keybase) Update(name, oldpass, newpass string) error {
+	info, err := kb.Get(name)
+	if err != nil {
+		return err
+	}
+	local, ok := info.(*localInfo)
+	if !ok {
+		return fmt.Errorf("key %q is not a local key, nothing to re-encrypt", name)
+	}
+	privKeyBytes, err := decryptLocalInfo(local, oldpass)
+	if err != nil {
+		return err
+	}
+	encryptedKey, err := generateKeyStore(privKeyBytes, newpass, nil)
+	if err != nil {
+		return err
+	}
+	encryptedJSON, err := json.Marshal(encryptedKey)
+	if err != nil {
+		return err
+	}
+	local.EncryptedPrivKey = string(encryptedJSON)
+	return kb.writeInfo(local)
+}
+
+func (kb *keybase) Sign(name, passphrase string, msg []byte) ([]byte, crypto.PubKey, error) {
+	info, err := kb.Get(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch i := info.(type) {
+	case *localInfo:
+		privKeyBytes, err := decryptLocalInfo(i, passphrase)
+		if err != nil {
+			return nil, nil, err
+		}
+		sig, err := privKeyBytes.Sign(msg)
+		if err != nil {
+			return nil, nil, err
+		}
+		return sig, privKeyBytes.PubKey(), nil
+	case *ledgerInfo:
+		if ledger.DiscoverLedger == nil {
+			return nil, nil, fmt.Errorf("no Ledger discovery function defined")
+		}
+		device, err := ledger.DiscoverLedger()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to find ledger device: %s", err.Error())
+		}
+		pkl, err := ledger.GenLedgerSecp256k1Key(i.Path, device)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create PrivKeyLedgerSecp256k1: %s", err.Error())
+		}
+		sig, err := pkl.Sign(msg)
+		if err != nil {
+			return nil, nil, err
+		}
+		return sig, pkl.PubKey(), nil
+	case *offlineInfo:
+		return nil, nil, fmt.Errorf("key %q is offline/watch-only: sign it with an external signer", name)
+	default:
+		return nil, nil, fmt.Errorf("key %q has unknown type", name)
+	}
+}
+
+// Export returns an ASCII-armored backup of the named key, encrypted under
+// passphrase. passphrase must be the one the key is stored under locally: it is
+// used both to decrypt the stored key and to encrypt the backup.
+func (kb *keybase) Export(name, passphrase string) (string, error) {
+	info, err := kb.Get(name)
+	if err != nil {
+		return "", err
+	}
+	local, ok := info.(*localInfo)
+	if !ok {
+		return "", fmt.Errorf("key %q is not a local key, nothing to export", name)
+	}
+	privKey, err := decryptLocalInfo(local, passphrase)
+	if err != nil {
+		return "", err
+	}
+	return encryptArmorPrivKey(privKey, passphrase)
+}
+
+func (kb *keybase) Import(name, armor, passphrase string) error {
+	if err := checkKeyName(name); err != nil {
+		return err
+	}
+	if _, err := kb.storage.Get(name); err == nil {
+		return fmt.Errorf("key %q already exists", name)
+	}
+	privKey, err := unarmorDecryptPrivKey(armor, passphrase)
+	if err != nil {
+		return err
+	}
+	secpPrivKey, ok := privKey.(secp256k1.PrivKeySecp256k1)
+	if !ok {
+		return fmt.Errorf(" Only PrivKeySecp256k1 key is supported ")
+	}
+	encryptedKey, err := generateKeyStore(secpPrivKey, passphrase, nil)
+	if err != nil {
+		return err
+	}
+	encryptedJSON, err := json.Marshal(encryptedKey)
+	if err != nil {
+		return err
+	}
+	local := newLocalInfo(name, secpPrivKey.PubKey(), string(encryptedJSON))
+	return kb.writeInfo(local)
+}
+
+// decryptLocalInfo decrypts the local key stored in info using passphrase,
+// returning the usable private key.
+func decryptLocalInfo(info *localInfo, passphrase string) (secp256k1.PrivKeySecp256k1, error) {
+	var encryptedKey EncryptedKeyJSON
+	if err := json.Unmarshal([]byte(info.EncryptedPrivKey), &encryptedKey); err != nil {
+		return secp256k1.PrivKeySecp256k1{}, err
+	}
+	keyBytes, err := decryptKey(&encryptedKey, passphrase)
+	if err != nil {
+		return secp256k1.PrivKeySecp256k1{}, err
+	}
+	if len(keyBytes) != 32 {
+		return secp256k1.PrivKeySecp256k1{}, fmt.Errorf("len of keybytes is not equal to 32")
+	}
+	var privKey secp256k1.PrivKeySecp256k1
+	copy(privKey[:], keyBytes[:32])
+	return privKey, nil
+}
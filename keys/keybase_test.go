@@ -0,0 +1,156 @@
+package keys
+
+import "testing"
+
+func newTestKeybase(t *testing.T) Keybase {
+	t.Helper()
+	return NewKeybaseWithStorage(NewInMemoryStorage())
+}
+
+func TestKeybaseCreateMnemonicAndGet(t *testing.T) {
+	kb := newTestKeybase(t)
+	created, mnemonic, err := kb.CreateMnemonic("alice", "passphrase", SigningAlgoSecp256k1)
+	if err != nil {
+		t.Fatalf("CreateMnemonic returned error: %s", err)
+	}
+	if mnemonic == "" {
+		t.Fatalf("CreateMnemonic returned an empty mnemonic")
+	}
+	if created.GetType() != TypeLocal {
+		t.Fatalf("expected TypeLocal, got %s", created.GetType())
+	}
+
+	got, err := kb.Get("alice")
+	if err != nil {
+		t.Fatalf("Get returned error: %s", err)
+	}
+	if got.GetAddress().String() != created.GetAddress().String() {
+		t.Fatalf("Get returned a different address than Create: %s != %s", got.GetAddress(), created.GetAddress())
+	}
+	if got.GetPubKey() == nil {
+		t.Fatalf("Get returned a nil pubkey; Info did not survive the storage round trip")
+	}
+}
+
+func TestKeybaseCreateMnemonicRejectsUnsupportedAlgo(t *testing.T) {
+	kb := newTestKeybase(t)
+	if _, _, err := kb.CreateMnemonic("alice", "passphrase", SigningAlgo("ed25519")); err == nil {
+		t.Fatalf("expected an error for an unsupported signing algo")
+	}
+}
+
+func TestKeybaseList(t *testing.T) {
+	kb := newTestKeybase(t)
+	if _, _, err := kb.CreateMnemonic("alice", "passphrase", SigningAlgoSecp256k1); err != nil {
+		t.Fatalf("CreateMnemonic returned error: %s", err)
+	}
+	if _, _, err := kb.CreateMnemonic("bob", "passphrase", SigningAlgoSecp256k1); err != nil {
+		t.Fatalf("CreateMnemonic returned error: %s", err)
+	}
+	infos, err := kb.List()
+	if err != nil {
+		t.Fatalf("List returned error: %s", err)
+	}
+	if len(infos) != 2 {
+		t.Fatalf("expected 2 keys, got %d", len(infos))
+	}
+}
+
+func TestKeybaseSignAndDelete(t *testing.T) {
+	kb := newTestKeybase(t)
+	created, _, err := kb.CreateMnemonic("alice", "passphrase", SigningAlgoSecp256k1)
+	if err != nil {
+		t.Fatalf("CreateMnemonic returned error: %s", err)
+	}
+
+	msg := []byte("sign me")
+	sig, pub, err := kb.Sign("alice", "passphrase", msg)
+	if err != nil {
+		t.Fatalf("Sign returned error: %s", err)
+	}
+	if !pub.VerifyBytes(msg, sig) {
+		t.Fatalf("signature produced by Sign does not verify against its own pubkey")
+	}
+	if pub.Address().String() != created.GetPubKey().Address().String() {
+		t.Fatalf("Sign returned a pubkey that doesn't match the one Create produced")
+	}
+
+	if _, _, err := kb.Sign("alice", "wrong-passphrase", msg); err == nil {
+		t.Fatalf("expected Sign with the wrong passphrase to fail")
+	}
+
+	if err := kb.Delete("alice", "wrong-passphrase"); err == nil {
+		t.Fatalf("expected Delete with the wrong passphrase to fail")
+	}
+	if err := kb.Delete("alice", "passphrase"); err != nil {
+		t.Fatalf("Delete returned error: %s", err)
+	}
+	if _, err := kb.Get("alice"); err == nil {
+		t.Fatalf("expected Get to fail after Delete")
+	}
+}
+
+func TestKeybaseCreateOfflineCannotSign(t *testing.T) {
+	kb := newTestKeybase(t)
+	local, _, err := kb.CreateMnemonic("alice", "passphrase", SigningAlgoSecp256k1)
+	if err != nil {
+		t.Fatalf("CreateMnemonic returned error: %s", err)
+	}
+
+	offline, err := kb.CreateOffline("watch-only", local.GetPubKey())
+	if err != nil {
+		t.Fatalf("CreateOffline returned error: %s", err)
+	}
+	if offline.GetType() != TypeOffline {
+		t.Fatalf("expected TypeOffline, got %s", offline.GetType())
+	}
+	if offline.GetAddress().String() != local.GetAddress().String() {
+		t.Fatalf("CreateOffline derived a different address than the underlying key")
+	}
+
+	if _, _, err := kb.Sign("watch-only", "", []byte("msg")); err == nil {
+		t.Fatalf("expected Sign on an offline key to fail")
+	}
+}
+
+func TestKeybaseExportImportRoundTrip(t *testing.T) {
+	kb := newTestKeybase(t)
+	created, _, err := kb.CreateMnemonic("alice", "passphrase", SigningAlgoSecp256k1)
+	if err != nil {
+		t.Fatalf("CreateMnemonic returned error: %s", err)
+	}
+
+	armor, err := kb.Export("alice", "passphrase")
+	if err != nil {
+		t.Fatalf("Export returned error: %s", err)
+	}
+
+	other := newTestKeybase(t)
+	if err := other.Import("alice-restored", armor, "passphrase"); err != nil {
+		t.Fatalf("Import returned error: %s", err)
+	}
+	restored, err := other.Get("alice-restored")
+	if err != nil {
+		t.Fatalf("Get returned error: %s", err)
+	}
+	if restored.GetAddress().String() != created.GetAddress().String() {
+		t.Fatalf("Import produced a different address than the original key: %s != %s",
+			restored.GetAddress(), created.GetAddress())
+	}
+}
+
+func TestKeybaseUpdate(t *testing.T) {
+	kb := newTestKeybase(t)
+	if _, _, err := kb.CreateMnemonic("alice", "old-pass", SigningAlgoSecp256k1); err != nil {
+		t.Fatalf("CreateMnemonic returned error: %s", err)
+	}
+	if err := kb.Update("alice", "old-pass", "new-pass"); err != nil {
+		t.Fatalf("Update returned error: %s", err)
+	}
+	if _, _, err := kb.Sign("alice", "old-pass", []byte("msg")); err == nil {
+		t.Fatalf("expected signing with the old passphrase to fail after Update")
+	}
+	if _, _, err := kb.Sign("alice", "new-pass", []byte("msg")); err != nil {
+		t.Fatalf("Sign with the new passphrase returned error: %s", err)
+	}
+}
@@ -1,7 +1,6 @@
 package keys
 
 import (
-	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -10,12 +9,10 @@ import (
 	"strings"
 
 	"github.com/cosmos/go-bip39"
-	"golang.org/x/crypto/pbkdf2"
 
-	"github.com/binance-chain/go-sdk/common"
 	"github.com/binance-chain/go-sdk/common/crypto"
 	"github.com/binance-chain/go-sdk/common/crypto/secp256k1"
-	"github.com/binance-chain/go-sdk/common/uuid"
+	"github.com/binance-chain/go-sdk/keys/hd"
 	"github.com/binance-chain/go-sdk/types"
 	"github.com/binance-chain/go-sdk/types/tx"
 
@@ -28,12 +25,18 @@ const (
 
 type KeyManager interface {
 	Sign(tx.StdSignMsg) ([]byte, error)
+	// SignStdTx produces a detached StdSignature for msg instead of an
+	// already-encoded, broadcast-ready StdTx, for offline/multisig signing flows.
+	// Combine the result with tx.AppendSignature and tx.EncodeStdTxForBroadcast.
+	SignStdTx(msg tx.StdSignMsg) (tx.StdSignature, error)
 	GetPrivKey() crypto.PrivKey
 	GetAddr() types.AccAddress
 
 	ExportAsMnemonic() (string, error)
 	ExportAsPrivateKey() (string, error)
-	ExportAsKeyStore(password string) (*EncryptedKeyJSON, error)
+	ExportAsKeyStore(password string, opts *KeyStoreOptions) (*EncryptedKeyJSON, error)
+	ExportAsArmor(passphrase string) (string, error)
+	ExportAsPubKeyArmor() (string, error)
 }
 
 func NewMnemonicKeyManager(mnemonic string) (KeyManager, error) {
@@ -42,6 +45,17 @@ func NewMnemonicKeyManager(mnemonic string) (KeyManager, error) {
 	return &k, err
 }
 
+// NewMnemonicKeyManagerWithPath recovers a key from mnemonic along an arbitrary
+// BIP44 path, optionally protected by a BIP39 passphrase. Unlike
+// NewMnemonicKeyManager, which always derives the first fundraiser address, this
+// lets callers derive any sibling account/addressIndex from the same mnemonic, or
+// restore a wallet that was created with a BIP39 passphrase.
+func NewMnemonicKeyManagerWithPath(mnemonic, bip39Passphrase string, path hd.BIP44Params) (KeyManager, error) {
+	k := keyManager{}
+	err := k.recoveryFromKMnemonicWithPath(mnemonic, bip39Passphrase, path)
+	return &k, err
+}
+
 func NewKeyStoreKeyManager(file string, auth string) (KeyManager, error) {
 	k := keyManager{}
 	err := k.recoveryFromKeyStore(file, auth)
@@ -60,6 +74,15 @@ func NewLedgerKeyManager(path ledger.DerivationPath) (KeyManager, error) {
 	return &k, err
 }
 
+// NewLedgerKeyManagerWithPath recovers a key from a connected Ledger device along
+// an arbitrary BIP44 path, letting callers reach the same account/addressIndex
+// pairs other BIP44-aware wallets (Trust, Ledger Live, ...) expose.
+func NewLedgerKeyManagerWithPath(path hd.BIP44Params) (KeyManager, error) {
+	k := keyManager{}
+	err := k.recoveryFromLedgerKey(ledger.DerivationPath(path.DerivationPath()))
+	return &k, err
+}
+
 type keyManager struct {
 	privKey  crypto.PrivKey
 	addr     types.AccAddress
@@ -81,8 +104,8 @@ func (m *keyManager) ExportAsPrivateKey() (string, error) {
 	return hex.EncodeToString(secpPrivateKey[:]), nil
 }
 
-func (m *keyManager) ExportAsKeyStore(password string) (*EncryptedKeyJSON, error) {
-	return generateKeyStore(m.GetPrivKey(), password)
+func (m *keyManager) ExportAsKeyStore(password string, opts *KeyStoreOptions) (*EncryptedKeyJSON, error) {
+	return generateKeyStore(m.GetPrivKey(), password, opts)
 }
 
 func NewKeyManager() (KeyManager, error) {
@@ -98,25 +121,41 @@ func NewKeyManager() (KeyManager, error) {
 }
 
 func (m *keyManager) recoveryFromKMnemonic(mnemonic string) error {
+	fundraiserPath, err := hd.NewParamsFromPath(FullFundraiserPath)
+	if err != nil {
+		return err
+	}
+	return m.recoveryFromKMnemonicWithPath(mnemonic, defaultBIP39Passphrase, fundraiserPath)
+}
+
+// checkMnemonic validates that mnemonic has a length BIP39 actually uses (12 or
+// 24 words), so a truncated or garbled mnemonic is rejected up front instead of
+// only failing if bip39.NewSeedWithErrorChecking happens to catch it, which
+// isn't guaranteed for every malformed length.
+func checkMnemonic(mnemonic string) error {
 	words := strings.Split(mnemonic, " ")
 	if len(words) != 12 && len(words) != 24 {
 		return fmt.Errorf("mnemonic length should either be 12 or 24")
 	}
-	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, defaultBIP39Passphrase)
+	return nil
+}
+
+func (m *keyManager) recoveryFromKMnemonicWithPath(mnemonic, bip39Passphrase string, path hd.BIP44Params) error {
+	if err := checkMnemonic(mnemonic); err != nil {
+		return err
+	}
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, bip39Passphrase)
 	if err != nil {
 		return err
 	}
-	// create master key and derive first key:
+	// create master key and derive the requested path:
 	masterPriv, ch := ComputeMastersFromSeed(seed)
-	derivedPriv, err := DerivePrivateKeyForPath(masterPriv, ch, FullFundraiserPath)
+	derivedPriv, err := DerivePrivateKeyForPath(masterPriv, ch, path.String())
 	if err != nil {
 		return err
 	}
 	priKey := secp256k1.PrivKeySecp256k1(derivedPriv)
 	addr := types.AccAddress(priKey.PubKey().Address())
-	if err != nil {
-		return err
-	}
 	m.addr = addr
 	m.privKey = priKey
 	m.mnemonic = mnemonic
@@ -206,6 +245,14 @@ func (m *keyManager) Sign(msg tx.StdSignMsg) ([]byte, error) {
 	return []byte(hex.EncodeToString(bz)), nil
 }
 
+// SignStdTx signs msg and returns just the StdSignature, without immediately
+// binary-encoding a broadcast-ready StdTx the way Sign does. Pass the result to
+// tx.AppendSignature to accumulate it onto a StdTx being built up for multisig,
+// or use it to return a signature from an air-gapped signing workstation.
+func (m *keyManager) SignStdTx(msg tx.StdSignMsg) (tx.StdSignature, error) {
+	return m.makeSignature(msg)
+}
+
 func (m *keyManager) GetPrivKey() crypto.PrivKey {
 	return m.privKey
 }
@@ -230,55 +277,3 @@ func (m *keyManager) makeSignature(msg tx.StdSignMsg) (sig tx.StdSignature, err
 	}, nil
 }
 
-func generateKeyStore(privateKey crypto.PrivKey, password string) (*EncryptedKeyJSON, error) {
-	addr := types.AccAddress(privateKey.PubKey().Address())
-	salt, err := common.GenerateRandomBytes(32)
-	if err != nil {
-		return nil, err
-	}
-	iv, err := common.GenerateRandomBytes(16)
-	if err != nil {
-		return nil, err
-	}
-	scryptParamsJSON := make(map[string]interface{}, 4)
-	scryptParamsJSON["prf"] = "hmac-sha256"
-	scryptParamsJSON["dklen"] = 32
-	scryptParamsJSON["salt"] = hex.EncodeToString(salt)
-	scryptParamsJSON["c"] = 262144
-
-	cipherParamsJSON := cipherparamsJSON{IV: hex.EncodeToString(iv)}
-	derivedKey := pbkdf2.Key([]byte(password), salt, 262144, 32, sha256.New)
-	encryptKey := derivedKey[:16]
-	secpPrivateKey, ok := privateKey.(secp256k1.PrivKeySecp256k1)
-	if !ok {
-		return nil, fmt.Errorf(" Only PrivKeySecp256k1 key is supported ")
-	}
-	cipherText, err := aesCTRXOR(encryptKey, secpPrivateKey[:], iv)
-	if err != nil {
-		return nil, err
-	}
-
-	hasher := sha256.New()
-	hasher.Write(derivedKey[16:32])
-	hasher.Write(cipherText)
-	mac := hasher.Sum(nil)
-
-	id, err := uuid.NewV4()
-	if err != nil {
-		return nil, err
-	}
-	cryptoStruct := CryptoJSON{
-		Cipher:       "aes-256-ctr",
-		CipherText:   hex.EncodeToString(cipherText),
-		CipherParams: cipherParamsJSON,
-		KDF:          "pbkdf2",
-		KDFParams:    scryptParamsJSON,
-		MAC:          hex.EncodeToString(mac),
-	}
-	return &EncryptedKeyJSON{
-		Address: addr.String(),
-		Crypto:  cryptoStruct,
-		Id:      id.String(),
-		Version: "1",
-	}, nil
-}
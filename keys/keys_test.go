@@ -0,0 +1,94 @@
+package keys
+
+import (
+	"testing"
+
+	"github.com/binance-chain/go-sdk/keys/hd"
+)
+
+const testMnemonic = "bottom quiz crowd laptop dove case ridge bid abstract blast mushroom shield tobacco mask bench scale fall thunder omit ten dizzy bullet jealous agree"
+
+func TestNewMnemonicKeyManagerWithPathMatchesDefault(t *testing.T) {
+	defaultManager, err := NewMnemonicKeyManager(testMnemonic)
+	if err != nil {
+		t.Fatalf("NewMnemonicKeyManager returned error: %s", err)
+	}
+
+	fundraiserPath, err := hd.NewParamsFromPath(FullFundraiserPath)
+	if err != nil {
+		t.Fatalf("hd.NewParamsFromPath returned error: %s", err)
+	}
+	pathManager, err := NewMnemonicKeyManagerWithPath(testMnemonic, "", fundraiserPath)
+	if err != nil {
+		t.Fatalf("NewMnemonicKeyManagerWithPath returned error: %s", err)
+	}
+
+	if defaultManager.GetAddr().String() != pathManager.GetAddr().String() {
+		t.Fatalf("expected NewMnemonicKeyManager and NewMnemonicKeyManagerWithPath with the fundraiser "+
+			"path to derive the same address, got %s and %s", defaultManager.GetAddr(), pathManager.GetAddr())
+	}
+}
+
+func TestNewMnemonicKeyManagerWithPathSiblingAddresses(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := uint32(0); i < 3; i++ {
+		manager, err := NewMnemonicKeyManagerWithPath(testMnemonic, "", hd.NewFundraiserParams(0, i))
+		if err != nil {
+			t.Fatalf("NewMnemonicKeyManagerWithPath(addressIndex=%d) returned error: %s", i, err)
+		}
+		addr := manager.GetAddr().String()
+		if seen[addr] {
+			t.Fatalf("addressIndex %d produced a duplicate address %s", i, addr)
+		}
+		seen[addr] = true
+	}
+}
+
+func TestNewMnemonicKeyManagerWithPathBip39Passphrase(t *testing.T) {
+	path := hd.NewFundraiserParams(0, 0)
+	withoutPassphrase, err := NewMnemonicKeyManagerWithPath(testMnemonic, "", path)
+	if err != nil {
+		t.Fatalf("NewMnemonicKeyManagerWithPath returned error: %s", err)
+	}
+	withPassphrase, err := NewMnemonicKeyManagerWithPath(testMnemonic, "extra secret", path)
+	if err != nil {
+		t.Fatalf("NewMnemonicKeyManagerWithPath returned error: %s", err)
+	}
+	if withoutPassphrase.GetAddr().String() == withPassphrase.GetAddr().String() {
+		t.Fatalf("expected a BIP39 passphrase to change the derived address")
+	}
+}
+
+// TestNewMnemonicKeyManagerWithPathKnownVector derives testMnemonic at the BIP44
+// fundraiser path and checks the result against an address independently
+// computed offline from the BIP39/BIP32/secp256k1 spec (PBKDF2-HMAC-SHA512 seed,
+// hardened/non-hardened HMAC-SHA512 child derivation, bech32("bnb",
+// ripemd160(sha256(compressed pubkey)))). The other tests in this file only
+// check internal self-consistency (siblings differ, derivation is
+// deterministic); this one catches a regression in the derivation math itself.
+func TestNewMnemonicKeyManagerWithPathKnownVector(t *testing.T) {
+	const expectedAddr = "bnb13amawlg3wgapwjvrtanwugnwauvpffr5657kw8"
+
+	manager, err := NewMnemonicKeyManagerWithPath(testMnemonic, "", hd.NewFundraiserParams(0, 0))
+	if err != nil {
+		t.Fatalf("NewMnemonicKeyManagerWithPath returned error: %s", err)
+	}
+	if manager.GetAddr().String() != expectedAddr {
+		t.Fatalf("expected address %s, got %s", expectedAddr, manager.GetAddr().String())
+	}
+}
+
+func TestNewMnemonicKeyManagerWithPathDeterministic(t *testing.T) {
+	path := hd.NewFundraiserParams(1, 2)
+	first, err := NewMnemonicKeyManagerWithPath(testMnemonic, "", path)
+	if err != nil {
+		t.Fatalf("NewMnemonicKeyManagerWithPath returned error: %s", err)
+	}
+	second, err := NewMnemonicKeyManagerWithPath(testMnemonic, "", path)
+	if err != nil {
+		t.Fatalf("NewMnemonicKeyManagerWithPath returned error: %s", err)
+	}
+	if first.GetAddr().String() != second.GetAddr().String() {
+		t.Fatalf("expected deriving the same mnemonic/path twice to be deterministic")
+	}
+}
@@ -0,0 +1,298 @@
+package keys
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/binance-chain/go-sdk/common"
+	"github.com/binance-chain/go-sdk/common/crypto"
+	"github.com/binance-chain/go-sdk/common/crypto/secp256k1"
+	"github.com/binance-chain/go-sdk/common/uuid"
+	"github.com/binance-chain/go-sdk/types"
+)
+
+// KDF identifies the key derivation function used to stretch a keystore's
+// passphrase into the AES key that protects the private key, following the
+// naming used by the Ethereum Web3 Secret Storage v3 format.
+type KDF string
+
+const (
+	KDFPbkdf2 KDF = "pbkdf2"
+	KDFScrypt KDF = "scrypt"
+)
+
+const (
+	defaultCipher           = "aes-128-ctr"
+	defaultPbkdf2Iterations = 262144
+	defaultPbkdf2DKLen      = 32
+	defaultScryptN          = 1 << 18
+	defaultScryptR          = 8
+	defaultScryptP          = 1
+	defaultScryptDKLen      = 32
+)
+
+// KeyStoreOptions controls how ExportAsKeyStore protects the exported private
+// key, letting callers trade off KDF and iteration count against the keystore's
+// compatibility with other Web3 Secret Storage v3 consumers (geth, MyEtherWallet,
+// other Cosmos tools, ...). A nil *KeyStoreOptions means DefaultKeyStoreOptions().
+type KeyStoreOptions struct {
+	KDF KDF
+
+	// Pbkdf2Iterations is the `c` parameter, only used when KDF is KDFPbkdf2.
+	Pbkdf2Iterations int
+
+	// ScryptN, ScryptR and ScryptP are the scrypt cost parameters, only used
+	// when KDF is KDFScrypt.
+	ScryptN int
+	ScryptR int
+	ScryptP int
+}
+
+// DefaultKeyStoreOptions matches the parameters this SDK has always used:
+// pbkdf2 with 2^18 iterations.
+func DefaultKeyStoreOptions() *KeyStoreOptions {
+	return &KeyStoreOptions{
+		KDF:              KDFPbkdf2,
+		Pbkdf2Iterations: defaultPbkdf2Iterations,
+	}
+}
+
+type cipherparamsJSON struct {
+	IV string `json:"iv"`
+}
+
+type CryptoJSON struct {
+	Cipher       string                 `json:"cipher"`
+	CipherText   string                 `json:"ciphertext"`
+	CipherParams cipherparamsJSON       `json:"cipherparams"`
+	KDF          string                 `json:"kdf"`
+	KDFParams    map[string]interface{} `json:"kdfparams"`
+	MAC          string                 `json:"mac"`
+}
+
+type EncryptedKeyJSON struct {
+	Address string     `json:"address"`
+	Crypto  CryptoJSON `json:"crypto"`
+	Id      string     `json:"id"`
+	Version string     `json:"version"`
+}
+
+func generateKeyStore(privateKey crypto.PrivKey, password string, opts *KeyStoreOptions) (*EncryptedKeyJSON, error) {
+	if opts == nil {
+		opts = DefaultKeyStoreOptions()
+	}
+	secpPrivateKey, ok := privateKey.(secp256k1.PrivKeySecp256k1)
+	if !ok {
+		return nil, fmt.Errorf(" Only PrivKeySecp256k1 key is supported ")
+	}
+	addr := types.AccAddress(privateKey.PubKey().Address())
+
+	salt, err := common.GenerateRandomBytes(32)
+	if err != nil {
+		return nil, err
+	}
+	iv, err := common.GenerateRandomBytes(16)
+	if err != nil {
+		return nil, err
+	}
+
+	var derivedKey []byte
+	var kdfParams map[string]interface{}
+	switch opts.KDF {
+	case KDFScrypt:
+		n, r, p := opts.ScryptN, opts.ScryptR, opts.ScryptP
+		if n == 0 {
+			n = defaultScryptN
+		}
+		if r == 0 {
+			r = defaultScryptR
+		}
+		if p == 0 {
+			p = defaultScryptP
+		}
+		derivedKey, err = scrypt.Key([]byte(password), salt, n, r, p, defaultScryptDKLen)
+		if err != nil {
+			return nil, err
+		}
+		kdfParams = map[string]interface{}{
+			"n":     n,
+			"r":     r,
+			"p":     p,
+			"dklen": defaultScryptDKLen,
+			"salt":  hex.EncodeToString(salt),
+		}
+	case "", KDFPbkdf2:
+		iterations := opts.Pbkdf2Iterations
+		if iterations == 0 {
+			iterations = defaultPbkdf2Iterations
+		}
+		derivedKey = pbkdf2.Key([]byte(password), salt, iterations, defaultPbkdf2DKLen, sha256.New)
+		kdfParams = map[string]interface{}{
+			"prf":   "hmac-sha256",
+			"dklen": defaultPbkdf2DKLen,
+			"salt":  hex.EncodeToString(salt),
+			"c":     iterations,
+		}
+	default:
+		return nil, fmt.Errorf("unsupported KDF %q", opts.KDF)
+	}
+
+	encryptKey := derivedKey[:16]
+	cipherText, err := aesCTRXOR(encryptKey, secpPrivateKey[:], iv)
+	if err != nil {
+		return nil, err
+	}
+
+	hasher := sha256.New()
+	hasher.Write(derivedKey[16:32])
+	hasher.Write(cipherText)
+	mac := hasher.Sum(nil)
+
+	id, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	cryptoStruct := CryptoJSON{
+		Cipher:       defaultCipher,
+		CipherText:   hex.EncodeToString(cipherText),
+		CipherParams: cipherparamsJSON{IV: hex.EncodeToString(iv)},
+		KDF:          string(opts.KDF),
+		KDFParams:    kdfParams,
+		MAC:          hex.EncodeToString(mac),
+	}
+	if cryptoStruct.KDF == "" {
+		cryptoStruct.KDF = string(KDFPbkdf2)
+	}
+	return &EncryptedKeyJSON{
+		Address: addr.String(),
+		Crypto:  cryptoStruct,
+		Id:      id.String(),
+		Version: "1",
+	}, nil
+}
+
+// decryptKey recovers the raw private key bytes protected by an EncryptedKeyJSON,
+// dispatching on crypto.kdf so keystores produced with either pbkdf2 or scrypt -
+// including ones from geth, MyEtherWallet or other Cosmos tools - can be imported.
+func decryptKey(encryptedKey *EncryptedKeyJSON, password string) ([]byte, error) {
+	salt, err := kdfParamHexString(encryptedKey.Crypto.KDFParams, "salt")
+	if err != nil {
+		return nil, err
+	}
+	saltBytes, err := hex.DecodeString(salt)
+	if err != nil {
+		return nil, err
+	}
+
+	var derivedKey []byte
+	switch KDF(encryptedKey.Crypto.KDF) {
+	case KDFScrypt:
+		n, err := kdfParamInt(encryptedKey.Crypto.KDFParams, "n")
+		if err != nil {
+			return nil, err
+		}
+		r, err := kdfParamInt(encryptedKey.Crypto.KDFParams, "r")
+		if err != nil {
+			return nil, err
+		}
+		p, err := kdfParamInt(encryptedKey.Crypto.KDFParams, "p")
+		if err != nil {
+			return nil, err
+		}
+		dklen, err := kdfParamInt(encryptedKey.Crypto.KDFParams, "dklen")
+		if err != nil {
+			return nil, err
+		}
+		derivedKey, err = scrypt.Key([]byte(password), saltBytes, n, r, p, dklen)
+		if err != nil {
+			return nil, err
+		}
+	case KDFPbkdf2:
+		c, err := kdfParamInt(encryptedKey.Crypto.KDFParams, "c")
+		if err != nil {
+			return nil, err
+		}
+		dklen, err := kdfParamInt(encryptedKey.Crypto.KDFParams, "dklen")
+		if err != nil {
+			return nil, err
+		}
+		derivedKey = pbkdf2.Key([]byte(password), saltBytes, c, dklen, sha256.New)
+	default:
+		return nil, fmt.Errorf("unsupported KDF %q", encryptedKey.Crypto.KDF)
+	}
+
+	if len(derivedKey) < 32 {
+		return nil, fmt.Errorf("derived key is only %d bytes, need at least 32", len(derivedKey))
+	}
+
+	cipherText, err := hex.DecodeString(encryptedKey.Crypto.CipherText)
+	if err != nil {
+		return nil, err
+	}
+
+	hasher := sha256.New()
+	hasher.Write(derivedKey[16:32])
+	hasher.Write(cipherText)
+	calculatedMAC := hasher.Sum(nil)
+	mac, err := hex.DecodeString(encryptedKey.Crypto.MAC)
+	if err != nil {
+		return nil, err
+	}
+	if hex.EncodeToString(calculatedMAC) != hex.EncodeToString(mac) {
+		return nil, fmt.Errorf("MAC mismatch: wrong passphrase")
+	}
+
+	iv, err := hex.DecodeString(encryptedKey.Crypto.CipherParams.IV)
+	if err != nil {
+		return nil, err
+	}
+	return aesCTRXOR(derivedKey[:16], cipherText, iv)
+}
+
+// kdfParamInt reads an integer-valued kdfparams entry, tolerating both the
+// int/float64 values produced in-process and the float64 values json.Unmarshal
+// always produces for map[string]interface{} values decoded from disk.
+func kdfParamInt(params map[string]interface{}, key string) (int, error) {
+	v, ok := params[key]
+	if !ok {
+		return 0, fmt.Errorf("kdfparams is missing %q", key)
+	}
+	switch n := v.(type) {
+	case int:
+		return n, nil
+	case float64:
+		return int(n), nil
+	default:
+		return 0, fmt.Errorf("kdfparams %q has unexpected type %T", key, v)
+	}
+}
+
+func kdfParamHexString(params map[string]interface{}, key string) (string, error) {
+	v, ok := params[key]
+	if !ok {
+		return "", fmt.Errorf("kdfparams is missing %q", key)
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("kdfparams %q has unexpected type %T", key, v)
+	}
+	return s, nil
+}
+
+func aesCTRXOR(key, inText, iv []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	stream := cipher.NewCTR(block, iv)
+	outText := make([]byte, len(inText))
+	stream.XORKeyStream(outText, inText)
+	return outText, nil
+}
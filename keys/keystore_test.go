@@ -0,0 +1,70 @@
+package keys
+
+import (
+	"testing"
+
+	"github.com/binance-chain/go-sdk/common/crypto/secp256k1"
+)
+
+func testPrivKey(t *testing.T) secp256k1.PrivKeySecp256k1 {
+	t.Helper()
+	km, err := NewKeyManager()
+	if err != nil {
+		t.Fatalf("NewKeyManager returned error: %s", err)
+	}
+	privKey, ok := km.GetPrivKey().(secp256k1.PrivKeySecp256k1)
+	if !ok {
+		t.Fatalf("NewKeyManager returned a non-secp256k1 private key")
+	}
+	return privKey
+}
+
+func TestGenerateKeyStorePbkdf2RoundTrip(t *testing.T) {
+	privKey := testPrivKey(t)
+	encryptedKey, err := generateKeyStore(privKey, "passphrase", DefaultKeyStoreOptions())
+	if err != nil {
+		t.Fatalf("generateKeyStore returned error: %s", err)
+	}
+	if encryptedKey.Crypto.KDF != string(KDFPbkdf2) {
+		t.Fatalf("expected KDF %q, got %q", KDFPbkdf2, encryptedKey.Crypto.KDF)
+	}
+
+	keyBytes, err := decryptKey(encryptedKey, "passphrase")
+	if err != nil {
+		t.Fatalf("decryptKey returned error: %s", err)
+	}
+	if string(keyBytes) != string(privKey[:]) {
+		t.Fatalf("decrypted key bytes do not match the original private key")
+	}
+}
+
+func TestGenerateKeyStoreScryptRoundTrip(t *testing.T) {
+	privKey := testPrivKey(t)
+	opts := &KeyStoreOptions{KDF: KDFScrypt, ScryptN: 1 << 12, ScryptR: 8, ScryptP: 1}
+	encryptedKey, err := generateKeyStore(privKey, "passphrase", opts)
+	if err != nil {
+		t.Fatalf("generateKeyStore returned error: %s", err)
+	}
+	if encryptedKey.Crypto.KDF != string(KDFScrypt) {
+		t.Fatalf("expected KDF %q, got %q", KDFScrypt, encryptedKey.Crypto.KDF)
+	}
+
+	keyBytes, err := decryptKey(encryptedKey, "passphrase")
+	if err != nil {
+		t.Fatalf("decryptKey returned error: %s", err)
+	}
+	if string(keyBytes) != string(privKey[:]) {
+		t.Fatalf("decrypted key bytes do not match the original private key")
+	}
+}
+
+func TestDecryptKeyWrongPassphrase(t *testing.T) {
+	privKey := testPrivKey(t)
+	encryptedKey, err := generateKeyStore(privKey, "passphrase", nil)
+	if err != nil {
+		t.Fatalf("generateKeyStore returned error: %s", err)
+	}
+	if _, err := decryptKey(encryptedKey, "wrong-passphrase"); err == nil {
+		t.Fatalf("expected decryptKey to fail with the wrong passphrase")
+	}
+}
@@ -0,0 +1,68 @@
+package keys
+
+import (
+	"fmt"
+
+	"github.com/binance-chain/go-sdk/common/crypto"
+	"github.com/binance-chain/go-sdk/types"
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+// offlineKeyManager is a watch-only KeyManager: it knows the public key and
+// address but holds no private material, so it cannot sign. It exists so a
+// workstation can build and serialize an unsigned StdSignMsg (with explicit
+// account number, sequence and chain-id) for an air-gapped machine or Ledger to
+// sign, instead of the workstation needing to hold a real key itself.
+type offlineKeyManager struct {
+	pubKey crypto.PubKey
+	addr   types.AccAddress
+}
+
+// NewOfflineKeyManager creates a KeyManager backed only by a public key. Every
+// method that would need the private key returns an error and GetPrivKey
+// returns nil; GetAddr and ExportAsPubKeyArmor, needed to hand work to an
+// external signer, still work.
+func NewOfflineKeyManager(pubKey crypto.PubKey) KeyManager {
+	return &offlineKeyManager{
+		pubKey: pubKey,
+		addr:   types.AccAddress(pubKey.Address()),
+	}
+}
+
+var errOffline = fmt.Errorf("this key is offline/watch-only: it holds no private key to sign with")
+
+func (m *offlineKeyManager) Sign(tx.StdSignMsg) ([]byte, error) {
+	return nil, errOffline
+}
+
+func (m *offlineKeyManager) SignStdTx(tx.StdSignMsg) (tx.StdSignature, error) {
+	return tx.StdSignature{}, errOffline
+}
+
+func (m *offlineKeyManager) GetPrivKey() crypto.PrivKey {
+	return nil
+}
+
+func (m *offlineKeyManager) GetAddr() types.AccAddress {
+	return m.addr
+}
+
+func (m *offlineKeyManager) ExportAsMnemonic() (string, error) {
+	return "", errOffline
+}
+
+func (m *offlineKeyManager) ExportAsPrivateKey() (string, error) {
+	return "", errOffline
+}
+
+func (m *offlineKeyManager) ExportAsKeyStore(password string, opts *KeyStoreOptions) (*EncryptedKeyJSON, error) {
+	return nil, errOffline
+}
+
+func (m *offlineKeyManager) ExportAsArmor(passphrase string) (string, error) {
+	return "", errOffline
+}
+
+func (m *offlineKeyManager) ExportAsPubKeyArmor() (string, error) {
+	return armorPubKey(m.pubKey), nil
+}
@@ -0,0 +1,61 @@
+package keys
+
+import (
+	"testing"
+
+	"github.com/binance-chain/go-sdk/types/tx"
+)
+
+func TestOfflineKeyManagerCannotSign(t *testing.T) {
+	km, err := NewKeyManager()
+	if err != nil {
+		t.Fatalf("NewKeyManager returned error: %s", err)
+	}
+
+	offline := NewOfflineKeyManager(km.GetPrivKey().PubKey())
+	if offline.GetAddr().String() != km.GetAddr().String() {
+		t.Fatalf("offline key manager has a different address: %s != %s", offline.GetAddr(), km.GetAddr())
+	}
+	if offline.GetPrivKey() != nil {
+		t.Fatalf("offline key manager should not hold a private key")
+	}
+
+	if _, err := offline.Sign(tx.StdSignMsg{}); err == nil {
+		t.Fatalf("expected Sign to fail on an offline key manager")
+	}
+	if _, err := offline.SignStdTx(tx.StdSignMsg{}); err == nil {
+		t.Fatalf("expected SignStdTx to fail on an offline key manager")
+	}
+	if _, err := offline.ExportAsMnemonic(); err == nil {
+		t.Fatalf("expected ExportAsMnemonic to fail on an offline key manager")
+	}
+	if _, err := offline.ExportAsPrivateKey(); err == nil {
+		t.Fatalf("expected ExportAsPrivateKey to fail on an offline key manager")
+	}
+	if _, err := offline.ExportAsKeyStore("passphrase", nil); err == nil {
+		t.Fatalf("expected ExportAsKeyStore to fail on an offline key manager")
+	}
+	if _, err := offline.ExportAsArmor("passphrase"); err == nil {
+		t.Fatalf("expected ExportAsArmor to fail on an offline key manager")
+	}
+}
+
+func TestOfflineKeyManagerExportAsPubKeyArmor(t *testing.T) {
+	km, err := NewKeyManager()
+	if err != nil {
+		t.Fatalf("NewKeyManager returned error: %s", err)
+	}
+	offline := NewOfflineKeyManager(km.GetPrivKey().PubKey())
+
+	armor, err := offline.ExportAsPubKeyArmor()
+	if err != nil {
+		t.Fatalf("ExportAsPubKeyArmor returned error: %s", err)
+	}
+	pub, err := UnarmorPubKey(armor)
+	if err != nil {
+		t.Fatalf("UnarmorPubKey returned error: %s", err)
+	}
+	if pub.Address().String() != km.GetAddr().String() {
+		t.Fatalf("UnarmorPubKey returned a pubkey with a different address")
+	}
+}
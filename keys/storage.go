@@ -0,0 +1,192 @@
+package keys
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	homedir "github.com/mitchellh/go-homedir"
+)
+
+const (
+	defaultKeyDBFolder = ".bnbcli"
+	defaultKeyDBName    = "keys"
+	infoFileSuffix      = ".info"
+)
+
+// KeybaseStorage is the pluggable persistence layer behind a Keybase. Downstream
+// applications that want keys backed by something other than the filesystem (a
+// mobile secure enclave, a database, ...) can implement this interface themselves
+// and hand it to NewKeybaseWithStorage.
+type KeybaseStorage interface {
+	Get(name string) ([]byte, error)
+	Put(name string, data []byte) error
+	Delete(name string) error
+	List() ([]string, error)
+}
+
+// defaultKeyDBDir returns ~/.bnbcli/keys, the default location for a file-backed Keybase.
+func defaultKeyDBDir() (string, error) {
+	home, err := homedir.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, defaultKeyDBFolder, defaultKeyDBName), nil
+}
+
+// fileStorage is the default KeybaseStorage implementation: one JSON file per key
+// name, under a base directory.
+type fileStorage struct {
+	baseDir string
+}
+
+// NewFileStorage creates a KeybaseStorage rooted at dir, creating the directory
+// (and any missing parents) if it does not yet exist.
+func NewFileStorage(dir string) (KeybaseStorage, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &fileStorage{baseDir: dir}, nil
+}
+
+func (s *fileStorage) path(name string) string {
+	return filepath.Join(s.baseDir, name+infoFileSuffix)
+}
+
+func (s *fileStorage) Get(name string) ([]byte, error) {
+	data, err := ioutil.ReadFile(s.path(name))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("key %q not found", name)
+	}
+	return data, err
+}
+
+func (s *fileStorage) Put(name string, data []byte) error {
+	return ioutil.WriteFile(s.path(name), data, 0600)
+}
+
+func (s *fileStorage) Delete(name string) error {
+	err := os.Remove(s.path(name))
+	if os.IsNotExist(err) {
+		return fmt.Errorf("key %q not found", name)
+	}
+	return err
+}
+
+func (s *fileStorage) List() ([]string, error) {
+	entries, err := ioutil.ReadDir(s.baseDir)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != infoFileSuffix {
+			continue
+		}
+		names = append(names, e.Name()[:len(e.Name())-len(infoFileSuffix)])
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// memoryStorage is an in-memory KeybaseStorage, mainly intended for tests and
+// short-lived, throwaway keybases.
+type memoryStorage struct {
+	mu   sync.RWMutex
+	data map[string][]byte
+}
+
+// NewInMemoryStorage creates a KeybaseStorage that keeps everything in process
+// memory; nothing is written to disk and its contents disappear when the process
+// exits.
+func NewInMemoryStorage() KeybaseStorage {
+	return &memoryStorage{data: make(map[string][]byte)}
+}
+
+func (s *memoryStorage) Get(name string) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	data, ok := s.data[name]
+	if !ok {
+		return nil, fmt.Errorf("key %q not found", name)
+	}
+	return data, nil
+}
+
+func (s *memoryStorage) Put(name string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[name] = data
+	return nil
+}
+
+func (s *memoryStorage) Delete(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.data[name]; !ok {
+		return fmt.Errorf("key %q not found", name)
+	}
+	delete(s.data, name)
+	return nil
+}
+
+func (s *memoryStorage) List() ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	names := make([]string, 0, len(s.data))
+	for name := range s.data {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func marshalInfo(info Info) ([]byte, error) {
+	data, err := json.Marshal(info)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(infoWrapper{Type: info.GetType(), Data: data})
+}
+
+func unmarshalInfo(raw []byte) (Info, error) {
+	var wrapper infoWrapper
+	if err := json.Unmarshal(raw, &wrapper); err != nil {
+		return nil, err
+	}
+	switch wrapper.Type {
+	case TypeLocal:
+		var i localInfo
+		if err := json.Unmarshal(wrapper.Data, &i); err != nil {
+			return nil, err
+		}
+		if err := i.hydrate(); err != nil {
+			return nil, err
+		}
+		return &i, nil
+	case TypeLedger:
+		var i ledgerInfo
+		if err := json.Unmarshal(wrapper.Data, &i); err != nil {
+			return nil, err
+		}
+		if err := i.hydrate(); err != nil {
+			return nil, err
+		}
+		return &i, nil
+	case TypeOffline:
+		var i offlineInfo
+		if err := json.Unmarshal(wrapper.Data, &i); err != nil {
+			return nil, err
+		}
+		if err := i.hydrate(); err != nil {
+			return nil, err
+		}
+		return &i, nil
+	default:
+		return nil, fmt.Errorf("unknown key type %d", wrapper.Type)
+	}
+}
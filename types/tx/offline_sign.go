@@ -0,0 +1,33 @@
+package tx
+
+import (
+	"encoding/hex"
+)
+
+// AppendSignature adds sig to stdTx and returns the updated transaction. It lets
+// a multisig or offline-signing workflow accumulate signatures one at a time -
+// e.g. folding in a signature produced by an air-gapped machine or a Ledger -
+// instead of requiring every signer to be available when the StdTx is built.
+//
+// It copies stdTx.Signatures into a freshly allocated slice rather than
+// appending in place: append can grow a slice into spare capacity of the
+// original backing array, which would let two independent signers building off
+// the same shared, unsigned StdTx silently clobber each other's signature.
+func AppendSignature(stdTx StdTx, sig StdSignature) (StdTx, error) {
+	signatures := make([]StdSignature, len(stdTx.Signatures), len(stdTx.Signatures)+1)
+	copy(signatures, stdTx.Signatures)
+	stdTx.Signatures = append(signatures, sig)
+	return stdTx, nil
+}
+
+// EncodeStdTxForBroadcast amino-marshals and hex-encodes stdTx into the same
+// wire format KeyManager.Sign produces, so a StdTx assembled out of detached
+// StdSignatures (see AppendSignature) can be broadcast the same way as one
+// signed in a single call.
+func EncodeStdTxForBroadcast(stdTx StdTx) ([]byte, error) {
+	bz, err := Cdc.MarshalBinaryLengthPrefixed(&stdTx)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(hex.EncodeToString(bz)), nil
+}
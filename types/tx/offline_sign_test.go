@@ -0,0 +1,51 @@
+package tx
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAppendSignature(t *testing.T) {
+	base := StdTx{}
+	sig := StdSignature{AccountNumber: 1, Sequence: 2}
+
+	updated, err := AppendSignature(base, sig)
+	if err != nil {
+		t.Fatalf("AppendSignature returned error: %s", err)
+	}
+	if len(updated.Signatures) != 1 {
+		t.Fatalf("expected 1 signature, got %d", len(updated.Signatures))
+	}
+	if !reflect.DeepEqual(updated.Signatures[0], sig) {
+		t.Fatalf("AppendSignature did not append the given signature")
+	}
+	if len(base.Signatures) != 0 {
+		t.Fatalf("AppendSignature mutated the original StdTx's Signatures")
+	}
+}
+
+// TestAppendSignatureDoesNotAliasSharedBackingArray guards against a regression
+// where append grows into spare capacity shared by two independent signers
+// working off the same unsigned StdTx: a first signer's append must not clobber
+// a second signer's.
+func TestAppendSignatureDoesNotAliasSharedBackingArray(t *testing.T) {
+	shared := StdTx{Signatures: make([]StdSignature, 0, 2)}
+	sigA := StdSignature{AccountNumber: 1}
+	sigB := StdSignature{AccountNumber: 2}
+
+	withA, err := AppendSignature(shared, sigA)
+	if err != nil {
+		t.Fatalf("AppendSignature returned error: %s", err)
+	}
+	withB, err := AppendSignature(shared, sigB)
+	if err != nil {
+		t.Fatalf("AppendSignature returned error: %s", err)
+	}
+
+	if len(withA.Signatures) != 1 || !reflect.DeepEqual(withA.Signatures[0], sigA) {
+		t.Fatalf("first signer's StdTx was corrupted: %+v", withA.Signatures)
+	}
+	if len(withB.Signatures) != 1 || !reflect.DeepEqual(withB.Signatures[0], sigB) {
+		t.Fatalf("second signer's StdTx was corrupted: %+v", withB.Signatures)
+	}
+}